@@ -1,59 +1,102 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"reflect"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the main structure of the matrix.yml file.
 type Config struct {
-	MCPServers   map[string]MCPServer `yaml:"mcpServers"`
-	LLM          LLM                  `yaml:"llm"`
-	Embedding    Embedding            `yaml:"embedding"`
-	SystemPrompt SystemPrompt         `yaml:"systemPrompt"`
-}
-
-// MCPServer represents the configuration for an MCP server.
-type MCPServer struct {
-	Type    string   `yaml:"type"`
-	Command string   `yaml:"command"`
-	Args    []string `yaml:"args"`
+	MCPServers   map[string]MCPServer `yaml:"mcpServers" json:"mcpServers"`
+	LLM          LLM                  `yaml:"llm" json:"llm"`
+	Embedding    Embedding            `yaml:"embedding" json:"embedding"`
+	SystemPrompt SystemPrompt         `yaml:"systemPrompt" json:"systemPrompt"`
 }
 
 // LLM represents the configuration for the Language Model.
 type LLM struct {
-	Provider      string `yaml:"provider"`
-	Model         string `yaml:"model"`
-	APIKey        string `yaml:"apiKey"`
-	MaxIterations int    `yaml:"maxIterations"`
+	Provider      string       `yaml:"provider" json:"provider"`
+	Model         string       `yaml:"model" json:"model"`
+	APIKey        SecretString `yaml:"apiKey" json:"apiKey"`
+	MaxIterations int          `yaml:"maxIterations" json:"maxIterations" default:"25"`
 }
 
 // Embedding represents the configuration for the embedding model.
 type Embedding struct {
-	Type   string `yaml:"type"`
-	Model  string `yaml:"model"`
-	APIKey string `yaml:"apiKey"`
+	Type   string       `yaml:"type" json:"type" default:"openai"`
+	Model  string       `yaml:"model" json:"model"`
+	APIKey SecretString `yaml:"apiKey" json:"apiKey"`
 }
 
 // SystemPrompt represents the configuration for the system prompt.
 type SystemPrompt struct {
-	Enabled bool   `yaml:"enabled"`
-	Content string `yaml:"content"`
+	Enabled bool   `yaml:"enabled" json:"enabled" default:"true"`
+	Content string `yaml:"content" json:"content"`
 }
 
-// LoadConfig loads the configuration from the given file path.
+// LoadConfig loads the configuration from the given file path. Decoding is
+// strict: unknown keys are rejected with a line-numbered error rather than
+// silently ignored. A file may contain more than one YAML document
+// (separated by "---"); documents are merged in order, later ones
+// overriding earlier ones. Once merged, any field left unset is filled in
+// by SetDefaults and the result is checked with Validate, so a malformed
+// or incomplete matrix.yml fails at startup instead of at first use.
 func LoadConfig(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
+	return LoadConfigs(path)
+}
+
+// LoadConfigs loads and merges the configuration from one or more file
+// paths, in order, so a base matrix.yml can be layered with per-project
+// or per-developer overrides (e.g. matrix.local.yml). Within and across
+// files, later documents take priority: scalar fields overwrite earlier
+// values, mcpServers entries are merged key by key, and a key tagged
+// !clear removes whatever an earlier document set for it.
+func LoadConfigs(paths ...string) (*Config, error) {
+	config := &Config{}
+	touched := make(map[string]bool)
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+
+		for {
+			var doc configDoc
+			err := decoder.Decode(&doc)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("parse %s: %w", path, err)
+			}
+
+			if err := mergeDocInto(config, doc, touched); err != nil {
+				return nil, fmt.Errorf("parse %s: %w", path, err)
+			}
+		}
 	}
 
-	var config Config
-	err = yaml.Unmarshal(data, &config)
-	if err != nil {
+	// Skip fields a document explicitly set, even to the zero value
+	// (e.g. "systemPrompt.enabled: false"), rather than treating them as
+	// omitted just because setDefaults can't otherwise tell the two
+	// apart.
+	if err := setDefaults(reflect.ValueOf(config).Elem(), "", touched); err != nil {
 		return nil, err
 	}
 
-	return &config, nil
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return config, nil
 }
@@ -1,13 +1,18 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"gopkg.in/yaml.v3"
 )
 
 func TestParseConfig(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-test-123")
+
 	yamlData := `
 mcpServers:
   filesystem:
@@ -42,18 +47,26 @@ systemPrompt:
 					"@modelcontextprotocol/server-filesystem",
 					".",
 				},
+				transport: StdioTransport{
+					Command: "npx",
+					Args: []string{
+						"-y",
+						"@modelcontextprotocol/server-filesystem",
+						".",
+					},
+				},
 			},
 		},
 		LLM: LLM{
 			Provider:      "openai",
 			Model:         "gpt-4.1-mini",
-			APIKey:        "$OPENAI_API_KEY",
+			APIKey:        "sk-test-123",
 			MaxIterations: 50,
 		},
 		Embedding: Embedding{
 			Type:   "openai",
 			Model:  "text-embedding-3-small",
-			APIKey: "$OPENAI_API_KEY",
+			APIKey: "sk-test-123",
 		},
 		SystemPrompt: SystemPrompt{
 			Enabled: true,
@@ -71,3 +84,84 @@ systemPrompt:
 		t.Errorf("Parsed config does not match expected config.\nGot: %+v\nWant: %+v", actual, *expected)
 	}
 }
+
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "matrix.yml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigAppliesDefaults(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-test-123")
+
+	path := writeConfig(t, `
+llm:
+  provider: openai
+  model: gpt-4.1-mini
+  apiKey: $OPENAI_API_KEY
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig returned an error: %v", err)
+	}
+
+	if cfg.LLM.MaxIterations != 25 {
+		t.Errorf("LLM.MaxIterations = %d, want 25", cfg.LLM.MaxIterations)
+	}
+	if cfg.Embedding.Type != "openai" {
+		t.Errorf("Embedding.Type = %q, want %q", cfg.Embedding.Type, "openai")
+	}
+}
+
+func TestLoadConfigRejectsUnknownFields(t *testing.T) {
+	path := writeConfig(t, `
+llm:
+  provider: openai
+  modle: gpt-4.1-mini
+`)
+
+	_, err := LoadConfig(path)
+	if err == nil {
+		t.Fatal("expected an error for an unknown field, got nil")
+	}
+	if !strings.Contains(err.Error(), "modle") {
+		t.Errorf("expected error to mention the unknown field, got: %v", err)
+	}
+}
+
+func TestLoadConfigRejectsUnknownMCPServerField(t *testing.T) {
+	path := writeConfig(t, `
+mcpServers:
+  filesystem:
+    command: npx
+    agrs:
+      - -y
+llm:
+  provider: openai
+  apiKey: test-key
+`)
+
+	_, err := LoadConfig(path)
+	if err == nil {
+		t.Fatal("expected an error for an unknown field, got nil")
+	}
+	if !strings.Contains(err.Error(), "agrs") {
+		t.Errorf("expected error to mention the unknown field, got: %v", err)
+	}
+}
+
+func TestLoadConfigRejectsInvalidConfig(t *testing.T) {
+	path := writeConfig(t, `
+llm:
+  provider: openai
+`)
+
+	_, err := LoadConfig(path)
+	if err == nil {
+		t.Fatal("expected an error for a missing API key, got nil")
+	}
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// SetDefaults fills any zero-valued field in cfg that carries a `default`
+// struct tag with the tag's value, walking nested structs recursively.
+// It is run after strict decoding so a config that omits optional keys
+// still ends up with the same values LoadConfig has always documented
+// (e.g. LLM.MaxIterations=25), without those values needing to be
+// spelled out in every matrix.yml.
+//
+// Called standalone like this, SetDefaults can't distinguish an omitted
+// bool field from one explicitly set to false, so a `default:"true"`
+// bool field always reads as true unless the key is present and set to
+// true in the YAML. LoadConfigs avoids that trap for merged documents by
+// calling setDefaults directly with the touched set setFieldsFromNode
+// built, so a field an override deliberately zeroed is left alone.
+func SetDefaults(cfg *Config) error {
+	return setDefaults(reflect.ValueOf(cfg).Elem(), "", nil)
+}
+
+func setDefaults(v reflect.Value, prefix string, touched map[string]bool) error {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+
+		path := field.Tag.Get("yaml")
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		if value.Kind() == reflect.Struct {
+			if err := setDefaults(value, path, touched); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("default")
+		if !ok || !value.IsZero() || touched[path] {
+			continue
+		}
+
+		if err := setDefault(value, tag); err != nil {
+			return fmt.Errorf("config: default for field %s: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func setDefault(value reflect.Value, tag string) error {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(tag)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(tag)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(tag, 10, 64)
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	default:
+		return fmt.Errorf("unsupported default kind %s", value.Kind())
+	}
+	return nil
+}
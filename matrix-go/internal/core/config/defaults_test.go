@@ -0,0 +1,42 @@
+package config
+
+import "testing"
+
+func TestSetDefaults(t *testing.T) {
+	cfg := &Config{
+		LLM:       LLM{Provider: "openai", Model: "gpt-4.1-mini"},
+		Embedding: Embedding{Model: "text-embedding-3-small"},
+	}
+
+	if err := SetDefaults(cfg); err != nil {
+		t.Fatalf("SetDefaults returned an error: %v", err)
+	}
+
+	if cfg.LLM.MaxIterations != 25 {
+		t.Errorf("LLM.MaxIterations = %d, want 25", cfg.LLM.MaxIterations)
+	}
+	if cfg.Embedding.Type != "openai" {
+		t.Errorf("Embedding.Type = %q, want %q", cfg.Embedding.Type, "openai")
+	}
+	if !cfg.SystemPrompt.Enabled {
+		t.Errorf("SystemPrompt.Enabled = false, want true")
+	}
+}
+
+func TestSetDefaultsDoesNotOverrideExplicitValues(t *testing.T) {
+	cfg := &Config{
+		LLM:       LLM{MaxIterations: 5},
+		Embedding: Embedding{Type: "ollama"},
+	}
+
+	if err := SetDefaults(cfg); err != nil {
+		t.Fatalf("SetDefaults returned an error: %v", err)
+	}
+
+	if cfg.LLM.MaxIterations != 5 {
+		t.Errorf("LLM.MaxIterations = %d, want 5", cfg.LLM.MaxIterations)
+	}
+	if cfg.Embedding.Type != "ollama" {
+		t.Errorf("Embedding.Type = %q, want %q", cfg.Embedding.Type, "ollama")
+	}
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlFieldIndex maps each yaml tag name declared on t's exported fields
+// to that field's index, so strict-decode and merge helpers can look up
+// "which Go field does this YAML key belong to" without hand-rolling the
+// struct tags at every call site.
+func yamlFieldIndex(t reflect.Type) map[string]int {
+	index := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		tag, ok := field.Tag.Lookup("yaml")
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		index[name] = i
+	}
+	return index
+}
+
+// jsonFieldKeys returns the set of json tag names declared on t's
+// exported fields, for rejecting unknown keys in a JSON object the same
+// way KnownFields(true) rejects them in YAML.
+func jsonFieldKeys(t reflect.Type) map[string]bool {
+	keys := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		tag, ok := field.Tag.Lookup("json")
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		keys[name] = true
+	}
+	return keys
+}
+
+// jsonToYAMLKeys maps each json tag name declared on t's exported fields
+// to that same field's yaml tag name. setDefaults walks fields by their
+// yaml tag, so code recording which JSON keys a document touched must
+// translate through this rather than assume a field's json and yaml tag
+// spellings always match.
+func jsonToYAMLKeys(t reflect.Type) map[string]string {
+	keys := make(map[string]string, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		jsonTag, ok := field.Tag.Lookup("json")
+		if !ok {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(jsonTag, ",")
+		yamlName, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		keys[jsonName] = yamlName
+	}
+	return keys
+}
+
+// checkKnownYAMLKeys returns an error naming the first key in node (a
+// mapping node) whose tag isn't in fields, line-numbered the same way
+// KnownFields(true) reports an unknown top-level key.
+func checkKnownYAMLKeys(node *yaml.Node, fields map[string]int) error {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		key := node.Content[i]
+		if key.Tag == "!!merge" {
+			// "<<: *anchor" is resolved into the anchor's own fields by
+			// value.Decode itself; it isn't a field name to check.
+			continue
+		}
+		if _, ok := fields[key.Value]; !ok {
+			return fmt.Errorf("line %d: unknown field %q", key.Line, key.Value)
+		}
+	}
+	return nil
+}
+
+// checkKnownJSONKeys returns an error naming the first key in data (a
+// JSON object) that isn't in known, the JSON counterpart to
+// checkKnownYAMLKeys.
+func checkKnownJSONKeys(data []byte, known map[string]bool) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for key := range raw {
+		if !known[key] {
+			return fmt.Errorf("unknown field %q", key)
+		}
+	}
+	return nil
+}
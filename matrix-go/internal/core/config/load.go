@@ -0,0 +1,139 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Load reads and parses the config at path, choosing the YAML or JSON
+// format by its extension (.yml/.yaml vs .json). Unlike LoadConfig it
+// only ever reads a single source and does not merge multiple documents;
+// use LoadConfigs for base+overlay setups.
+func Load(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	format := "json"
+	if ext := strings.ToLower(filepath.Ext(path)); ext != ".json" {
+		format = "yaml"
+	}
+
+	return LoadFromReader(f, format)
+}
+
+// LoadFromReader parses a Config out of r in the given format ("yaml" or
+// "json"), so callers can feed a config in from stdin, an embedded FS, or
+// a Kubernetes ConfigMap watcher without going through the filesystem.
+// YAML input is converted to JSON before unmarshaling into Config: since
+// both formats decode into the same struct, every field only needs one
+// set of json-facing Unmarshal methods (SecretString, MCPServer, ...),
+// and a ConfigMap-fed admin API can reuse that same JSON path directly.
+func LoadFromReader(r io.Reader, format string) (*Config, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case "yaml", "yml":
+		data, err = yamlToJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("convert yaml to json: %w", err)
+		}
+	case "json":
+		// already in the shared representation
+	default:
+		return nil, fmt.Errorf("config: unsupported format %q", format)
+	}
+
+	var config Config
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+
+	// Track which llm/embedding/systemPrompt fields data actually sets,
+	// the same way mergeDocInto does for LoadConfigs, so a field
+	// deliberately set back to its zero value (e.g. "enabled": false)
+	// isn't then overwritten by its `default` tag.
+	touched, err := jsonTouchedFields(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+
+	if err := setDefaults(reflect.ValueOf(&config).Elem(), "", touched); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return &config, nil
+}
+
+// jsonTouchedFields returns the dotted paths (e.g. "systemPrompt.enabled")
+// of every field present under data's llm, embedding and systemPrompt
+// objects, mirroring setFieldsFromNode's touched-set for the YAML/
+// multi-document merge path in merge.go. Paths are spelled using each
+// field's yaml tag - the convention setDefaults walks by - translated
+// from the JSON key via jsonToYAMLKeys rather than assumed to match it.
+func jsonTouchedFields(data []byte) (map[string]bool, error) {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return nil, err
+	}
+
+	sections := []struct {
+		name string
+		typ  reflect.Type
+	}{
+		{"llm", reflect.TypeOf(LLM{})},
+		{"embedding", reflect.TypeOf(Embedding{})},
+		{"systemPrompt", reflect.TypeOf(SystemPrompt{})},
+	}
+
+	touched := make(map[string]bool)
+	for _, section := range sections {
+		raw, ok := top[section.name]
+		if !ok {
+			continue
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, err
+		}
+		toYAML := jsonToYAMLKeys(section.typ)
+		for key := range fields {
+			touched[section.name+"."+toYAML[key]] = true
+		}
+	}
+
+	return touched, nil
+}
+
+// yamlToJSON walks data as a generic YAML document and re-encodes it as
+// JSON. yaml.v3 already decodes mappings with string keys into
+// map[string]interface{}, so the walk is just "decode then re-marshal" -
+// there is no map[interface{}]interface{} surprise to iron out as there
+// would be with yaml.v2.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var walked interface{}
+	if err := yaml.Unmarshal(data, &walked); err != nil {
+		return nil, err
+	}
+	return json.Marshal(walked)
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadJSON(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-test-123")
+
+	path := writeFile(t, "matrix.json", `{
+		"llm": {
+			"provider": "openai",
+			"model": "gpt-4.1-mini",
+			"apiKey": "$OPENAI_API_KEY",
+			"maxIterations": 50
+		},
+		"mcpServers": {
+			"filesystem": {"type": "stdio", "command": "npx"}
+		}
+	}`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned an error: %v", err)
+	}
+
+	if cfg.LLM.APIKey != "sk-test-123" {
+		t.Errorf("LLM.APIKey = %q, want %q", cfg.LLM.APIKey, "sk-test-123")
+	}
+	if cfg.MCPServers["filesystem"].Command != "npx" {
+		t.Errorf("MCPServers[filesystem].Command = %q, want %q", cfg.MCPServers["filesystem"].Command, "npx")
+	}
+}
+
+func TestLoadYAMLDispatchesByExtension(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-test-123")
+
+	path := writeFile(t, "matrix.yml", `
+llm:
+  provider: openai
+  apiKey: $OPENAI_API_KEY
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned an error: %v", err)
+	}
+
+	if cfg.LLM.APIKey != "sk-test-123" {
+		t.Errorf("LLM.APIKey = %q, want %q", cfg.LLM.APIKey, "sk-test-123")
+	}
+	if cfg.LLM.MaxIterations != 25 {
+		t.Errorf("LLM.MaxIterations = %d, want 25 (defaults still applied)", cfg.LLM.MaxIterations)
+	}
+}
+
+func TestLoadJSONRejectsUnknownMCPServerField(t *testing.T) {
+	path := writeFile(t, "matrix.json", `{
+		"llm": {"provider": "openai", "apiKey": "test-key"},
+		"mcpServers": {
+			"filesystem": {"type": "stdio", "command": "npx", "agrs": ["-y"]}
+		}
+	}`)
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatal("expected an error for an unknown field, got nil")
+	}
+	if !strings.Contains(err.Error(), "agrs") {
+		t.Errorf("expected error to mention the unknown field, got: %v", err)
+	}
+}
+
+func TestLoadJSONCanOverrideDefaultToZeroValue(t *testing.T) {
+	path := writeFile(t, "matrix.json", `{
+		"llm": {"provider": "openai", "apiKey": "test-key", "maxIterations": 0},
+		"systemPrompt": {"enabled": false}
+	}`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned an error: %v", err)
+	}
+
+	if cfg.LLM.MaxIterations != 0 {
+		t.Errorf("LLM.MaxIterations = %d, want 0 (explicitly set, not defaulted)", cfg.LLM.MaxIterations)
+	}
+	if cfg.SystemPrompt.Enabled {
+		t.Error("SystemPrompt.Enabled = true, want false (explicitly set, not defaulted)")
+	}
+}
+
+func TestLoadYAMLCanOverrideDefaultToZeroValue(t *testing.T) {
+	path := writeFile(t, "matrix.yml", `
+llm:
+  provider: openai
+  apiKey: test-key
+systemPrompt:
+  enabled: false
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned an error: %v", err)
+	}
+
+	if cfg.SystemPrompt.Enabled {
+		t.Error("SystemPrompt.Enabled = true, want false (explicitly set, not defaulted)")
+	}
+}
+
+func TestLoadFromReaderRejectsUnknownFormat(t *testing.T) {
+	_, err := LoadFromReader(strings.NewReader(""), "toml")
+	if err == nil {
+		t.Fatal("expected an error for an unsupported format, got nil")
+	}
+}
@@ -0,0 +1,244 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MCPServer represents the configuration for an MCP server. It is a
+// discriminated union on Type: stdio servers are spawned as a local
+// process (Command/Args/Env), while sse and http servers are reached
+// over the network (URL/Headers and friends). The fields relevant to
+// the server's Type are decoded by UnmarshalYAML/UnmarshalJSON, which
+// also validate that the fields required for that Type are present and
+// build the private transport used by the MCP client factory.
+type MCPServer struct {
+	Type string `yaml:"type" json:"type"`
+
+	// stdio
+	Command string                  `yaml:"command" json:"command"`
+	Args    []string                `yaml:"args" json:"args"`
+	Env     map[string]SecretString `yaml:"env" json:"env"`
+
+	// sse and http
+	URL              string            `yaml:"url" json:"url"`
+	Headers          map[string]string `yaml:"headers" json:"headers"`
+	ReconnectBackoff Duration          `yaml:"reconnectBackoff" json:"reconnectBackoff"`
+
+	// http
+	BearerToken SecretString `yaml:"bearerToken" json:"bearerToken"`
+	OAuth       *OAuthConfig `yaml:"oauth" json:"oauth"`
+
+	transport transportConfig
+}
+
+// OAuthConfig describes the OAuth client-credentials exchange used to
+// obtain a bearer token for an http MCP server, as an alternative to a
+// static BearerToken.
+type OAuthConfig struct {
+	ClientID     SecretString `yaml:"clientId" json:"clientId"`
+	ClientSecret SecretString `yaml:"clientSecret" json:"clientSecret"`
+	TokenURL     string       `yaml:"tokenUrl" json:"tokenUrl"`
+	Scopes       []string     `yaml:"scopes" json:"scopes"`
+}
+
+var (
+	oauthConfigYAMLFields = yamlFieldIndex(reflect.TypeOf(OAuthConfig{}))
+	oauthConfigJSONKeys   = jsonFieldKeys(reflect.TypeOf(OAuthConfig{}))
+)
+
+// UnmarshalYAML decodes an OAuthConfig, rejecting unknown keys the same
+// way MCPServer.UnmarshalYAML does - needed because oauth is reached via
+// a plain value.Decode inside MCPServer's own UnmarshalYAML, which
+// doesn't otherwise propagate strictness into a nested struct.
+func (o *OAuthConfig) UnmarshalYAML(value *yaml.Node) error {
+	if err := checkKnownYAMLKeys(value, oauthConfigYAMLFields); err != nil {
+		return fmt.Errorf("oauth: %w", err)
+	}
+
+	type rawOAuthConfig OAuthConfig
+	var raw rawOAuthConfig
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	*o = OAuthConfig(raw)
+	return nil
+}
+
+// UnmarshalJSON decodes an OAuthConfig from JSON, the JSON counterpart
+// to UnmarshalYAML.
+func (o *OAuthConfig) UnmarshalJSON(data []byte) error {
+	if err := checkKnownJSONKeys(data, oauthConfigJSONKeys); err != nil {
+		return fmt.Errorf("oauth: %w", err)
+	}
+
+	type rawOAuthConfig OAuthConfig
+	var raw rawOAuthConfig
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*o = OAuthConfig(raw)
+	return nil
+}
+
+// Duration is a time.Duration that decodes from a Go duration string
+// (e.g. "2s", "500ms") instead of a raw integer of nanoseconds, which is
+// friendlier in a hand-written matrix.yml.
+type Duration time.Duration
+
+// UnmarshalYAML parses d from a duration string via time.ParseDuration.
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	var raw string
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	return d.parse(raw)
+}
+
+// UnmarshalJSON parses d from a JSON string via time.ParseDuration.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return d.parse(raw)
+}
+
+func (d *Duration) parse(raw string) error {
+	if raw == "" {
+		*d = 0
+		return nil
+	}
+	parsed, err := time.ParseDuration(raw)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", raw, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// transportConfig is the private, per-Type view of an MCPServer that the
+// MCP client factory dispatches on to build the right transport. It has
+// no methods of its own; its purpose is to give each Type its own Go
+// type so the factory's switch is exhaustive and type-safe.
+type transportConfig interface {
+	mcpTransport()
+}
+
+// StdioTransport is the transport config for a locally spawned MCP
+// server communicating over stdin/stdout.
+type StdioTransport struct {
+	Command string
+	Args    []string
+	Env     map[string]SecretString
+}
+
+func (StdioTransport) mcpTransport() {}
+
+// SSETransport is the transport config for an MCP server reached over
+// Server-Sent Events.
+type SSETransport struct {
+	URL              string
+	Headers          map[string]string
+	ReconnectBackoff time.Duration
+}
+
+func (SSETransport) mcpTransport() {}
+
+// HTTPTransport is the transport config for an MCP server reached over
+// the streamable HTTP transport.
+type HTTPTransport struct {
+	URL         string
+	Headers     map[string]string
+	BearerToken SecretString
+	OAuth       *OAuthConfig
+}
+
+func (HTTPTransport) mcpTransport() {}
+
+// Transport returns the typed transport config built for this server by
+// UnmarshalYAML/UnmarshalJSON, for use by the MCP client factory.
+func (m MCPServer) Transport() transportConfig {
+	return m.transport
+}
+
+// finalize validates the fields required for m's Type and builds its
+// private transport config. It is shared by UnmarshalYAML and
+// UnmarshalJSON so the two formats apply exactly the same rules.
+func (m *MCPServer) finalize() error {
+	if m.Type == "" {
+		m.Type = "stdio"
+	}
+
+	switch m.Type {
+	case "stdio":
+		if m.Command == "" {
+			return fmt.Errorf("mcpServers: command is required for type %q", m.Type)
+		}
+		m.transport = StdioTransport{Command: m.Command, Args: m.Args, Env: m.Env}
+	case "sse":
+		if m.URL == "" {
+			return fmt.Errorf("mcpServers: url is required for type %q", m.Type)
+		}
+		m.transport = SSETransport{URL: m.URL, Headers: m.Headers, ReconnectBackoff: time.Duration(m.ReconnectBackoff)}
+	case "http":
+		if m.URL == "" {
+			return fmt.Errorf("mcpServers: url is required for type %q", m.Type)
+		}
+		m.transport = HTTPTransport{URL: m.URL, Headers: m.Headers, BearerToken: m.BearerToken, OAuth: m.OAuth}
+	default:
+		return fmt.Errorf("mcpServers: unknown type %q, must be one of stdio|sse|http", m.Type)
+	}
+
+	return nil
+}
+
+var (
+	mcpServerYAMLFields = yamlFieldIndex(reflect.TypeOf(MCPServer{}))
+	mcpServerJSONKeys   = jsonFieldKeys(reflect.TypeOf(MCPServer{}))
+)
+
+// UnmarshalYAML decodes an MCPServer, validates the fields required for
+// its Type, and populates the private transport config matching that
+// Type. Unknown keys are rejected the same way LoadConfigs' top-level
+// KnownFields(true) rejects them, since that option only applies to the
+// document yaml.Decode walks itself and doesn't propagate into a nested
+// type's own UnmarshalYAML.
+func (m *MCPServer) UnmarshalYAML(value *yaml.Node) error {
+	if err := checkKnownYAMLKeys(value, mcpServerYAMLFields); err != nil {
+		return fmt.Errorf("mcpServers: %w", err)
+	}
+
+	type rawMCPServer MCPServer
+	var raw rawMCPServer
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	*m = MCPServer(raw)
+	return m.finalize()
+}
+
+// UnmarshalJSON decodes an MCPServer from JSON, applying the same
+// per-Type validation, transport construction and unknown-key rejection
+// as UnmarshalYAML.
+func (m *MCPServer) UnmarshalJSON(data []byte) error {
+	if err := checkKnownJSONKeys(data, mcpServerJSONKeys); err != nil {
+		return fmt.Errorf("mcpServers: %w", err)
+	}
+
+	type rawMCPServer MCPServer
+	var raw rawMCPServer
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*m = MCPServer(raw)
+	return m.finalize()
+}
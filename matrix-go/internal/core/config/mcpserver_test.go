@@ -0,0 +1,135 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMCPServerSSETransport(t *testing.T) {
+	yamlData := `
+type: sse
+url: https://example.com/mcp
+headers:
+  Authorization: Bearer abc
+reconnectBackoff: 2s
+`
+
+	var server MCPServer
+	if err := yaml.Unmarshal([]byte(yamlData), &server); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	transport, ok := server.Transport().(SSETransport)
+	if !ok {
+		t.Fatalf("Transport() = %T, want SSETransport", server.Transport())
+	}
+	if transport.URL != "https://example.com/mcp" {
+		t.Errorf("URL = %q, want %q", transport.URL, "https://example.com/mcp")
+	}
+	if transport.ReconnectBackoff != 2*time.Second {
+		t.Errorf("ReconnectBackoff = %v, want 2s", transport.ReconnectBackoff)
+	}
+}
+
+func TestMCPServerHTTPTransport(t *testing.T) {
+	yamlData := `
+type: http
+url: https://example.com/mcp
+bearerToken: secret-token
+`
+
+	var server MCPServer
+	if err := yaml.Unmarshal([]byte(yamlData), &server); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	transport, ok := server.Transport().(HTTPTransport)
+	if !ok {
+		t.Fatalf("Transport() = %T, want HTTPTransport", server.Transport())
+	}
+	if transport.BearerToken != "secret-token" {
+		t.Errorf("BearerToken = %q, want %q", transport.BearerToken, "secret-token")
+	}
+}
+
+func TestMCPServerRequiresURLForSSE(t *testing.T) {
+	var server MCPServer
+	err := yaml.Unmarshal([]byte("type: sse\n"), &server)
+	if err == nil {
+		t.Fatal("expected an error for a missing url, got nil")
+	}
+}
+
+func TestMCPServerUnknownType(t *testing.T) {
+	var server MCPServer
+	err := yaml.Unmarshal([]byte("type: websocket\n"), &server)
+	if err == nil {
+		t.Fatal("expected an error for an unknown type, got nil")
+	}
+}
+
+func TestMCPServerUnmarshalYAMLRejectsUnknownField(t *testing.T) {
+	var server MCPServer
+	err := yaml.Unmarshal([]byte("command: npx\nagrs: [-y]\n"), &server)
+	if err == nil {
+		t.Fatal("expected an error for an unknown field, got nil")
+	}
+	if !strings.Contains(err.Error(), "agrs") {
+		t.Errorf("expected error to mention the unknown field, got: %v", err)
+	}
+}
+
+func TestMCPServerUnmarshalJSONRejectsUnknownField(t *testing.T) {
+	var server MCPServer
+	err := json.Unmarshal([]byte(`{"type":"stdio","command":"npx","agrs":["-y"]}`), &server)
+	if err == nil {
+		t.Fatal("expected an error for an unknown field, got nil")
+	}
+	if !strings.Contains(err.Error(), "agrs") {
+		t.Errorf("expected error to mention the unknown field, got: %v", err)
+	}
+}
+
+func TestMCPServerUnmarshalYAMLRejectsUnknownOAuthField(t *testing.T) {
+	yamlData := `
+type: http
+url: https://example.com/mcp
+oauth:
+  clientId: abc
+  scoeps: [read]
+`
+	var server MCPServer
+	err := yaml.Unmarshal([]byte(yamlData), &server)
+	if err == nil {
+		t.Fatal("expected an error for an unknown field, got nil")
+	}
+	if !strings.Contains(err.Error(), "scoeps") {
+		t.Errorf("expected error to mention the unknown field, got: %v", err)
+	}
+}
+
+func TestMCPServerUnmarshalJSONRejectsUnknownOAuthField(t *testing.T) {
+	data := `{"type":"http","url":"https://example.com/mcp","oauth":{"clientId":"abc","scoeps":["read"]}}`
+	var server MCPServer
+	err := json.Unmarshal([]byte(data), &server)
+	if err == nil {
+		t.Fatal("expected an error for an unknown field, got nil")
+	}
+	if !strings.Contains(err.Error(), "scoeps") {
+		t.Errorf("expected error to mention the unknown field, got: %v", err)
+	}
+}
+
+func TestMCPServerDefaultsToStdio(t *testing.T) {
+	var server MCPServer
+	if err := yaml.Unmarshal([]byte("command: npx\n"), &server); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+	if server.Type != "stdio" {
+		t.Errorf("Type = %q, want %q", server.Type, "stdio")
+	}
+}
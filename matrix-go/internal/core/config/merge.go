@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// clearTag is applied to an mcpServers entry (e.g. "filesystem: !clear")
+// to remove a key inherited from an earlier document or file instead of
+// overriding it.
+const clearTag = "!clear"
+
+// configDoc mirrors Config but keeps each section as a raw yaml.Node
+// instead of decoding it immediately. That lets mergeDocInto see which
+// keys doc actually sets (mcpServers' tag, or which scalar fields are
+// present in llm/embedding/systemPrompt) before deciding how to layer it
+// onto cfg - a plain struct field can't be told apart from "the key was
+// simply absent".
+type configDoc struct {
+	MCPServers   map[string]yaml.Node `yaml:"mcpServers"`
+	LLM          yaml.Node            `yaml:"llm"`
+	Embedding    yaml.Node            `yaml:"embedding"`
+	SystemPrompt yaml.Node            `yaml:"systemPrompt"`
+}
+
+// mergeDocInto layers doc onto cfg: mcpServers entries are merged key by
+// key (a later document's key wins, and a key tagged !clear is removed),
+// while each field doc actually sets under llm, embedding and
+// systemPrompt overwrites cfg's value - including overwriting it back to
+// the zero value, e.g. "systemPrompt.enabled: false" after an earlier
+// "enabled: true". touched records every such field's dotted path
+// (e.g. "systemPrompt.enabled") so SetDefaults can tell a field an
+// override deliberately zeroed apart from one no document ever
+// mentioned. YAML anchors and merge keys (<<:) are resolved by yaml.v3
+// before mergeDocInto ever sees the document, so they need no special
+// handling here.
+func mergeDocInto(cfg *Config, doc configDoc, touched map[string]bool) error {
+	if len(doc.MCPServers) > 0 && cfg.MCPServers == nil {
+		cfg.MCPServers = make(map[string]MCPServer, len(doc.MCPServers))
+	}
+	for name, node := range doc.MCPServers {
+		node := node
+		if node.Tag == clearTag {
+			delete(cfg.MCPServers, name)
+			continue
+		}
+
+		var server MCPServer
+		if err := node.Decode(&server); err != nil {
+			return fmt.Errorf("mcpServers.%s: %w", name, err)
+		}
+		cfg.MCPServers[name] = server
+	}
+
+	if err := setFieldsFromNode(reflect.ValueOf(&cfg.LLM).Elem(), doc.LLM, "llm", touched); err != nil {
+		return fmt.Errorf("llm: %w", err)
+	}
+	if err := setFieldsFromNode(reflect.ValueOf(&cfg.Embedding).Elem(), doc.Embedding, "embedding", touched); err != nil {
+		return fmt.Errorf("embedding: %w", err)
+	}
+	if err := setFieldsFromNode(reflect.ValueOf(&cfg.SystemPrompt).Elem(), doc.SystemPrompt, "systemPrompt", touched); err != nil {
+		return fmt.Errorf("systemPrompt: %w", err)
+	}
+
+	return nil
+}
+
+// setFieldsFromNode decodes every key present in node (a mapping, or the
+// zero yaml.Node if the section was omitted entirely) onto the matching
+// field of dst, recording each field's dotted path (prefix + "." + key)
+// in touched. Unlike copying only non-zero values, this can set a field
+// back to its zero value, since presence in node - not zero-ness of the
+// decoded value - is what it checks. An unknown key is rejected the same
+// way KnownFields(true) rejects one, since decoding a section as a raw
+// yaml.Node here bypasses the top-level decoder's own check.
+func setFieldsFromNode(dst reflect.Value, node yaml.Node, prefix string, touched map[string]bool) error {
+	if node.Kind == 0 {
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", node.Line)
+	}
+
+	fields := yamlFieldIndex(dst.Type())
+	for i := 0; i < len(node.Content); i += 2 {
+		key := node.Content[i]
+		value := node.Content[i+1]
+
+		idx, ok := fields[key.Value]
+		if !ok {
+			return fmt.Errorf("line %d: unknown field %q", key.Line, key.Value)
+		}
+
+		if err := value.Decode(dst.Field(idx).Addr().Interface()); err != nil {
+			return err
+		}
+		touched[prefix+"."+key.Value] = true
+	}
+
+	return nil
+}
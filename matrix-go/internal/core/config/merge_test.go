@@ -0,0 +1,157 @@
+package config
+
+import "testing"
+
+func TestLoadConfigsOverlaysLaterFiles(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-test-123")
+
+	base := writeConfig(t, `
+llm:
+  provider: openai
+  model: gpt-4.1-mini
+  apiKey: $OPENAI_API_KEY
+  maxIterations: 10
+mcpServers:
+  filesystem:
+    type: stdio
+    command: npx
+`)
+	local := writeConfig(t, `
+llm:
+  maxIterations: 40
+mcpServers:
+  search:
+    type: stdio
+    command: search-server
+`)
+
+	cfg, err := LoadConfigs(base, local)
+	if err != nil {
+		t.Fatalf("LoadConfigs returned an error: %v", err)
+	}
+
+	if cfg.LLM.MaxIterations != 40 {
+		t.Errorf("LLM.MaxIterations = %d, want 40 (overridden by later file)", cfg.LLM.MaxIterations)
+	}
+	if cfg.LLM.Model != "gpt-4.1-mini" {
+		t.Errorf("LLM.Model = %q, want it preserved from the base file", cfg.LLM.Model)
+	}
+	if len(cfg.MCPServers) != 2 {
+		t.Fatalf("len(MCPServers) = %d, want 2 (merged key by key)", len(cfg.MCPServers))
+	}
+	if _, ok := cfg.MCPServers["filesystem"]; !ok {
+		t.Error("expected the base file's filesystem server to survive the merge")
+	}
+	if _, ok := cfg.MCPServers["search"]; !ok {
+		t.Error("expected the later file's search server to be added")
+	}
+}
+
+func TestLoadConfigsClearTagRemovesKey(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-test-123")
+
+	base := writeConfig(t, `
+llm:
+  provider: openai
+  apiKey: $OPENAI_API_KEY
+mcpServers:
+  filesystem:
+    type: stdio
+    command: npx
+`)
+	local := writeConfig(t, `
+mcpServers:
+  filesystem: !clear
+`)
+
+	cfg, err := LoadConfigs(base, local)
+	if err != nil {
+		t.Fatalf("LoadConfigs returned an error: %v", err)
+	}
+
+	if _, ok := cfg.MCPServers["filesystem"]; ok {
+		t.Error("expected filesystem to be removed by the !clear tag")
+	}
+}
+
+func TestLoadConfigMultiDocument(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-test-123")
+
+	path := writeConfig(t, `
+llm:
+  provider: openai
+  apiKey: $OPENAI_API_KEY
+  maxIterations: 10
+---
+llm:
+  maxIterations: 20
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig returned an error: %v", err)
+	}
+
+	if cfg.LLM.MaxIterations != 20 {
+		t.Errorf("LLM.MaxIterations = %d, want 20 (overridden by the second document)", cfg.LLM.MaxIterations)
+	}
+}
+
+func TestLoadConfigsOverlayCanClearToZeroValue(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-test-123")
+
+	base := writeConfig(t, `
+llm:
+  provider: openai
+  apiKey: $OPENAI_API_KEY
+systemPrompt:
+  enabled: true
+  content: you are a helpful assistant
+`)
+	local := writeConfig(t, `
+systemPrompt:
+  enabled: false
+`)
+
+	cfg, err := LoadConfigs(base, local)
+	if err != nil {
+		t.Fatalf("LoadConfigs returned an error: %v", err)
+	}
+
+	if cfg.SystemPrompt.Enabled {
+		t.Error("SystemPrompt.Enabled = true, want false (overridden by the later file)")
+	}
+	if cfg.SystemPrompt.Content != "you are a helpful assistant" {
+		t.Errorf("SystemPrompt.Content = %q, want it preserved from the base file", cfg.SystemPrompt.Content)
+	}
+}
+
+func TestLoadConfigsHonorsAnchors(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-test-123")
+
+	path := writeConfig(t, `
+mcpServers:
+  base: &base
+    type: stdio
+    command: npx
+  filesystem:
+    <<: *base
+    args: ["-y"]
+llm:
+  provider: openai
+  apiKey: $OPENAI_API_KEY
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig returned an error: %v", err)
+	}
+
+	fs, ok := cfg.MCPServers["filesystem"]
+	if !ok {
+		t.Fatal("expected a filesystem server")
+	}
+	if fs.Command != "npx" {
+		t.Errorf("Command = %q, want %q (inherited via anchor)", fs.Command, "npx")
+	}
+}
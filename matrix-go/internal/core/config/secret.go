@@ -0,0 +1,153 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SecretString is a string-valued config field that is resolved once at
+// YAML decode time. Plain values are passed through os.ExpandEnv so
+// "$OPENAI_API_KEY" and "${OPENAI_API_KEY}" are expanded in place. Values
+// of the form "scheme:rest" are instead handed to the secret provider
+// registered for that scheme (see RegisterSecretProvider), which lets
+// LLM.APIKey, Embedding.APIKey and similar fields reference a secret
+// manager (e.g. "file:/run/secrets/openai" or, once EnableCommandSecrets
+// has been called, "cmd:pass show openai/key") without the placeholder
+// ever leaking into a committed config file.
+type SecretString string
+
+var (
+	secretProvidersMu sync.RWMutex
+	secretProviders   = map[string]func(string) (string, error){
+		"env":  envSecretProvider,
+		"file": fileSecretProvider,
+	}
+)
+
+// RegisterSecretProvider registers fn as the resolver for URIs of the form
+// "scheme:rest" (e.g. RegisterSecretProvider("vault", resolveFromVault)
+// resolves "vault:secret/data/openai#key"). Registering a scheme that is
+// already registered replaces it. The built-in "env", "file" and "cmd"
+// schemes may be overridden the same way.
+func RegisterSecretProvider(scheme string, fn func(string) (string, error)) {
+	secretProvidersMu.Lock()
+	defer secretProvidersMu.Unlock()
+	secretProviders[scheme] = fn
+}
+
+func resolveSecret(raw string) (string, error) {
+	if scheme, rest, ok := strings.Cut(raw, ":"); ok {
+		secretProvidersMu.RLock()
+		fn, registered := secretProviders[scheme]
+		secretProvidersMu.RUnlock()
+		if registered {
+			resolved, err := fn(rest)
+			if err != nil {
+				return "", fmt.Errorf("resolve secret %q: %w", raw, err)
+			}
+			return resolved, nil
+		}
+	}
+	return os.ExpandEnv(raw), nil
+}
+
+func envSecretProvider(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %q is not set", name)
+	}
+	return value, nil
+}
+
+func fileSecretProvider(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func cmdSecretProvider(command string) (string, error) {
+	cmd := exec.Command("sh", "-c", command)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("run %q: %w", command, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// EnableCommandSecrets registers the "cmd:" secret provider, which runs
+// its value as a shell command and resolves the secret to its trimmed
+// stdout. It is opt-in rather than built in alongside "env" and "file"
+// because, unlike those, it executes arbitrary shell: a host binary that
+// feeds untrusted input into Load/LoadFromReader/LoadConfig(s) (an admin
+// API accepting a config payload, a ConfigMap watcher) must not call
+// this unless every caller able to reach that config is already trusted
+// to run commands on the host.
+func EnableCommandSecrets() {
+	RegisterSecretProvider("cmd", cmdSecretProvider)
+}
+
+// UnmarshalYAML resolves the scalar value via resolveSecret as it is
+// decoded, so downstream code only ever sees the final secret value.
+func (s *SecretString) UnmarshalYAML(value *yaml.Node) error {
+	var raw string
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	resolved, err := resolveSecret(raw)
+	if err != nil {
+		return err
+	}
+
+	*s = SecretString(resolved)
+	return nil
+}
+
+// UnmarshalJSON resolves the string value via resolveSecret as it is
+// decoded, the JSON counterpart to UnmarshalYAML.
+func (s *SecretString) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	resolved, err := resolveSecret(raw)
+	if err != nil {
+		return err
+	}
+
+	*s = SecretString(resolved)
+	return nil
+}
+
+// redactedSecret stands in for a SecretString's real value wherever it
+// might be formatted rather than deliberately read, e.g. Config printed
+// with %+v for debug logging or wrapped into an error.
+const redactedSecret = "***"
+
+// String implements fmt.Stringer with a redacted placeholder rather than
+// the resolved secret, so formatting a Config (or an LLM/Embedding/
+// MCPServer field within it) via %v/%+v can't leak an API key or bearer
+// token into logs. Code that genuinely needs the resolved value, such as
+// setting a subprocess's environment, must call Reveal instead.
+func (s SecretString) String() string {
+	if s == "" {
+		return ""
+	}
+	return redactedSecret
+}
+
+// Reveal returns the resolved secret value. Only call this where the
+// value is actually needed (e.g. an Authorization header or a spawned
+// process's environment) - never to format it for logging or errors.
+func (s SecretString) Reveal() string {
+	return string(s)
+}
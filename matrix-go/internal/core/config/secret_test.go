@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestSecretStringExpandsEnv(t *testing.T) {
+	t.Setenv("MATRIX_TEST_SECRET", "from-env")
+
+	var s SecretString
+	if err := yaml.Unmarshal([]byte(`${MATRIX_TEST_SECRET}`), &s); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if s != "from-env" {
+		t.Errorf("got %q, want %q", s, "from-env")
+	}
+}
+
+func TestSecretStringFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write secret file: %v", err)
+	}
+
+	var s SecretString
+	if err := yaml.Unmarshal([]byte("file:"+path), &s); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if s != "from-file" {
+		t.Errorf("got %q, want %q", s, "from-file")
+	}
+}
+
+func TestSecretStringStringIsRedacted(t *testing.T) {
+	s := SecretString("sk-super-secret")
+
+	if got := s.String(); got != redactedSecret {
+		t.Errorf("String() = %q, want %q", got, redactedSecret)
+	}
+	if got := fmt.Sprintf("%v", s); got != redactedSecret {
+		t.Errorf("%%v formatting = %q, want %q", got, redactedSecret)
+	}
+	if got := s.Reveal(); got != "sk-super-secret" {
+		t.Errorf("Reveal() = %q, want the resolved secret", got)
+	}
+}
+
+func TestSecretStringStringOfEmptyIsEmpty(t *testing.T) {
+	var s SecretString
+	if got := s.String(); got != "" {
+		t.Errorf("String() of an unset secret = %q, want empty", got)
+	}
+}
+
+func TestCommandSecretsAreNotRegisteredByDefault(t *testing.T) {
+	var s SecretString
+	err := yaml.Unmarshal([]byte("cmd:echo from-command"), &s)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	// With no "cmd" provider registered, resolveSecret falls back to
+	// os.ExpandEnv, which leaves a value with no $VAR references as-is.
+	if s != "cmd:echo from-command" {
+		t.Errorf("got %q, want the raw value passed through unresolved", s)
+	}
+}
+
+func TestEnableCommandSecrets(t *testing.T) {
+	EnableCommandSecrets()
+
+	var s SecretString
+	if err := yaml.Unmarshal([]byte("cmd:echo -n from-command"), &s); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if s != "from-command" {
+		t.Errorf("got %q, want %q", s, "from-command")
+	}
+}
+
+func TestRegisterSecretProvider(t *testing.T) {
+	RegisterSecretProvider("test", func(rest string) (string, error) {
+		return "resolved-" + rest, nil
+	})
+
+	var s SecretString
+	if err := yaml.Unmarshal([]byte(`test:thing`), &s); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if s != "resolved-thing" {
+		t.Errorf("got %q, want %q", s, "resolved-thing")
+	}
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// validProviders maps an LLM/embedding provider name to whether it
+// requires an API key to be set.
+var validProviders = map[string]bool{
+	"openai":    true,
+	"anthropic": true,
+	"ollama":    false,
+}
+
+var validMCPServerTypes = map[string]bool{
+	"stdio": true,
+	"sse":   true,
+	"http":  true,
+}
+
+// FieldError describes a single validation failure on a config field,
+// identified by its dotted path (e.g. "mcpServers.filesystem.type").
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// FieldErrors aggregates every FieldError found during Validate so callers
+// see all misconfigurations at once instead of fixing them one at a time.
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fe := range e {
+		messages[i] = fe.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validate checks that cfg describes a startable configuration: known
+// provider/model combinations, required API keys, and well-formed MCP
+// server entries. It returns a FieldErrors aggregating every problem
+// found, or nil if cfg is valid.
+func (c *Config) Validate() error {
+	var errs FieldErrors
+
+	errs = append(errs, validateProvider("llm", "provider", c.LLM.Provider, c.LLM.APIKey)...)
+	// Embedding.Type defaults to "openai" via SetDefaults even when the
+	// embedding section is entirely absent, so only validate it once the
+	// user has actually configured a model - otherwise every config
+	// without embeddings would fail validation demanding an API key.
+	if c.Embedding.Model != "" {
+		errs = append(errs, validateProvider("embedding", "type", c.Embedding.Type, c.Embedding.APIKey)...)
+	}
+
+	for name, server := range c.MCPServers {
+		field := fmt.Sprintf("mcpServers.%s", name)
+		if !validMCPServerTypes[server.Type] {
+			errs = append(errs, &FieldError{
+				Field:   field + ".type",
+				Message: fmt.Sprintf("must be one of stdio|sse|http, got %q", server.Type),
+			})
+		}
+		if server.Type == "stdio" && server.Command == "" {
+			errs = append(errs, &FieldError{
+				Field:   field + ".command",
+				Message: "required when type is stdio",
+			})
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func validateProvider(section, providerKey string, provider string, apiKey SecretString) FieldErrors {
+	var errs FieldErrors
+
+	if provider == "" {
+		return errs
+	}
+
+	requiresKey, known := validProviders[provider]
+	if !known {
+		errs = append(errs, &FieldError{
+			Field:   section + "." + providerKey,
+			Message: fmt.Sprintf("unknown provider %q", provider),
+		})
+		return errs
+	}
+
+	if requiresKey && apiKey == "" {
+		errs = append(errs, &FieldError{
+			Field:   section + ".apiKey",
+			Message: fmt.Sprintf("required for provider %q", provider),
+		})
+	}
+
+	return errs
+}
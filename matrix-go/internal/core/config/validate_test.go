@@ -0,0 +1,52 @@
+package config
+
+import "testing"
+
+func TestValidateRequiresAPIKeyForProvider(t *testing.T) {
+	cfg := &Config{LLM: LLM{Provider: "openai", Model: "gpt-4.1-mini"}}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected an error for a missing API key, got nil")
+	}
+
+	fieldErrs, ok := err.(FieldErrors)
+	if !ok || len(fieldErrs) != 1 || fieldErrs[0].Field != "llm.apiKey" {
+		t.Errorf("got %v, want a single llm.apiKey FieldError", err)
+	}
+}
+
+func TestValidateUnknownProvider(t *testing.T) {
+	cfg := &Config{LLM: LLM{Provider: "bogus"}}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected an error for an unknown provider, got nil")
+	}
+}
+
+func TestValidateMCPServerType(t *testing.T) {
+	cfg := &Config{
+		MCPServers: map[string]MCPServer{
+			"filesystem": {Type: "websocket", Command: "npx"},
+		},
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected an error for an invalid MCP server type, got nil")
+	}
+}
+
+func TestValidateOK(t *testing.T) {
+	cfg := &Config{
+		LLM: LLM{Provider: "openai", APIKey: "sk-test"},
+		MCPServers: map[string]MCPServer{
+			"filesystem": {Type: "stdio", Command: "npx"},
+		},
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected a valid config, got error: %v", err)
+	}
+}
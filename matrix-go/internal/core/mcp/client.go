@@ -0,0 +1,37 @@
+// Package mcp builds MCP client connections from a config.MCPServer,
+// dispatching on its Type to the transport the server was configured
+// for (a locally spawned stdio process, or a networked sse/http server).
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/renegade-studio/matrix/internal/core/config"
+)
+
+// Client is an MCP server connection. Concrete implementations wrap the
+// transport-specific plumbing (a spawned process, an SSE stream, an
+// HTTP session) behind the same start/stop lifecycle.
+type Client interface {
+	// Start establishes the connection, spawning the server process or
+	// opening the network connection as appropriate for the transport.
+	Start() error
+	// Close tears down the connection and releases its resources.
+	Close() error
+}
+
+// NewClient builds the Client for server, dispatching on server.Type to
+// the matching transport. It returns an error if server's Type is not
+// one of stdio, sse or http.
+func NewClient(server config.MCPServer) (Client, error) {
+	switch transport := server.Transport().(type) {
+	case config.StdioTransport:
+		return newStdioClient(transport), nil
+	case config.SSETransport:
+		return newSSEClient(transport), nil
+	case config.HTTPTransport:
+		return newHTTPClient(transport), nil
+	default:
+		return nil, fmt.Errorf("mcp: unsupported server type %q", server.Type)
+	}
+}
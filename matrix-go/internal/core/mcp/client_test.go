@@ -0,0 +1,50 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/renegade-studio/matrix/internal/core/config"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewClientDispatchesOnType(t *testing.T) {
+	tests := []struct {
+		yaml string
+		want Client
+	}{
+		{"type: stdio\ncommand: npx\n", &stdioClient{}},
+		{"type: sse\nurl: https://example.com/mcp\n", &sseClient{}},
+		{"type: http\nurl: https://example.com/mcp\n", &httpClient{}},
+	}
+
+	for _, tt := range tests {
+		var server config.MCPServer
+		if err := yaml.Unmarshal([]byte(tt.yaml), &server); err != nil {
+			t.Fatalf("Failed to unmarshal YAML: %v", err)
+		}
+
+		client, err := NewClient(server)
+		if err != nil {
+			t.Fatalf("NewClient returned an error: %v", err)
+		}
+
+		gotType := typeName(client)
+		wantType := typeName(tt.want)
+		if gotType != wantType {
+			t.Errorf("NewClient(%q) = %s, want %s", tt.yaml, gotType, wantType)
+		}
+	}
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case *stdioClient:
+		return "stdioClient"
+	case *sseClient:
+		return "sseClient"
+	case *httpClient:
+		return "httpClient"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,32 @@
+package mcp
+
+import (
+	"net/http"
+
+	"github.com/renegade-studio/matrix/internal/core/config"
+)
+
+// httpClient is an MCP server reached over the streamable HTTP
+// transport. Start is a no-op beyond readying the http.Client: unlike
+// stdio and sse, the streamable HTTP transport is request/response, so
+// there is no long-lived connection to establish up front.
+type httpClient struct {
+	transport config.HTTPTransport
+	client    *http.Client
+}
+
+func newHTTPClient(transport config.HTTPTransport) *httpClient {
+	return &httpClient{
+		transport: transport,
+		client:    &http.Client{},
+	}
+}
+
+func (c *httpClient) Start() error {
+	return nil
+}
+
+func (c *httpClient) Close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
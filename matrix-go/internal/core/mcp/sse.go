@@ -0,0 +1,54 @@
+package mcp
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/renegade-studio/matrix/internal/core/config"
+)
+
+// sseClient is an MCP server reached over Server-Sent Events, with its
+// own http.Client so a dropped stream can be reconnected independently
+// of the caller.
+type sseClient struct {
+	transport config.SSETransport
+	client    *http.Client
+	resp      *http.Response
+}
+
+func newSSEClient(transport config.SSETransport) *sseClient {
+	return &sseClient{
+		transport: transport,
+		client:    &http.Client{},
+	}
+}
+
+func (c *sseClient) Start() error {
+	req, err := http.NewRequest(http.MethodGet, c.transport.URL, nil)
+	if err != nil {
+		return fmt.Errorf("mcp: build request for %q: %w", c.transport.URL, err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	for name, value := range c.transport.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("mcp: connect to %q: %w", c.transport.URL, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return fmt.Errorf("mcp: connect to %q: unexpected status %s", c.transport.URL, resp.Status)
+	}
+
+	c.resp = resp
+	return nil
+}
+
+func (c *sseClient) Close() error {
+	if c.resp == nil {
+		return nil
+	}
+	return c.resp.Body.Close()
+}
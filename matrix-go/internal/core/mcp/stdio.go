@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/renegade-studio/matrix/internal/core/config"
+)
+
+// stdioClient is an MCP server spawned as a local subprocess,
+// communicating over its stdin/stdout pipes.
+type stdioClient struct {
+	transport config.StdioTransport
+	cmd       *exec.Cmd
+}
+
+func newStdioClient(transport config.StdioTransport) *stdioClient {
+	return &stdioClient{transport: transport}
+}
+
+func (c *stdioClient) Start() error {
+	cmd := exec.Command(c.transport.Command, c.transport.Args...)
+	cmd.Env = os.Environ()
+	for name, value := range c.transport.Env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", name, value.Reveal()))
+	}
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("mcp: start %q: %w", c.transport.Command, err)
+	}
+
+	c.cmd = cmd
+	return nil
+}
+
+func (c *stdioClient) Close() error {
+	if c.cmd == nil || c.cmd.Process == nil {
+		return nil
+	}
+	return c.cmd.Process.Kill()
+}